@@ -0,0 +1,78 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/xGihyun/typetest/session"
+	"github.com/xGihyun/typetest/storage"
+)
+
+// screen selects which sub-model app renders and forwards messages to.
+type screen int
+
+const (
+	screenTyping screen = iota
+	screenHistory
+)
+
+// app is the root bubbletea model; it owns the typing test and switches to
+// the history view once a run is finished.
+type app struct {
+	screen  screen
+	typing  model
+	history historyModel
+	store   *storage.Store
+}
+
+func newApp(cfg session.TestConfig, text session.Text, store *storage.Store) app {
+	return app{
+		screen: screenTyping,
+		typing: initialModel(cfg, text, store),
+		store:  store,
+	}
+}
+
+func (a app) Init() tea.Cmd {
+	return a.typing.Init()
+}
+
+func (a app) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if a.screen == screenHistory {
+		if key, ok := msg.(tea.KeyMsg); ok {
+			switch key.Type {
+			case tea.KeyCtrlC:
+				return a, tea.Quit
+			case tea.KeyEsc:
+				a.screen = screenTyping
+				return a, nil
+			}
+		}
+
+		var cmd tea.Cmd
+		a.history, cmd = a.history.Update(msg)
+		return a, cmd
+	}
+
+	if key, ok := msg.(tea.KeyMsg); ok && a.typing.finished && key.Type == tea.KeyTab {
+		a.history = loadHistoryModel(a.store)
+		a.screen = screenHistory
+		return a, a.history.Init()
+	}
+
+	updated, cmd := a.typing.Update(msg)
+	a.typing = updated.(model)
+	return a, cmd
+}
+
+func (a app) View() string {
+	if a.screen == screenHistory {
+		return a.history.View()
+	}
+
+	view := a.typing.View()
+	if a.typing.finished {
+		view += "\n\ntab: view history"
+	}
+
+	return view
+}
@@ -0,0 +1,87 @@
+// Command typetest-server hosts the typing test over SSH so multiple
+// players can race the same generated text together.
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	wishbubbletea "github.com/charmbracelet/wish/bubbletea"
+	"github.com/charmbracelet/wish/logging"
+
+	"github.com/xGihyun/typetest/race"
+	"github.com/xGihyun/typetest/wordlist"
+)
+
+const (
+	host        = "0.0.0.0"
+	port        = "2222"
+	hostKeyPath = ".ssh/typetest_server_ed25519"
+	raceWords   = 50
+)
+
+func main() {
+	hub := newRaceHub()
+
+	server, err := wish.NewServer(
+		wish.WithAddress(net.JoinHostPort(host, port)),
+		wish.WithHostKeyPath(hostKeyPath),
+		wish.WithMiddleware(
+			wishbubbletea.Middleware(func(s ssh.Session) (tea.Model, []tea.ProgramOption) {
+				name := s.User()
+				if name == "" {
+					name = s.RemoteAddr().String()
+				}
+
+				id := s.RemoteAddr().String()
+				if pk := s.PublicKey(); pk != nil {
+					id = string(pk.Marshal())
+				}
+
+				return race.NewModel(hub, id, name), []tea.ProgramOption{tea.WithAltScreen()}
+			}),
+			logging.Middleware(),
+		),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	done := make(chan os.Signal, 1)
+	signal.Notify(done, os.Interrupt, syscall.SIGTERM)
+
+	log.Printf("starting race server on %s:%s", host, port)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, ssh.ErrServerClosed) {
+			log.Fatal(err)
+		}
+	}()
+
+	<-done
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// newRaceHub generates the shared ghost text every player in this server's
+// race will type.
+func newRaceHub() *race.Hub {
+	words, err := wordlist.Generate(wordlist.DefaultLanguage, raceWords, wordlist.Options{})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return race.NewHub(words.Display)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/xGihyun/typetest/storage"
+)
+
+const historyRecentLimit = 10
+
+// historyModel is the history/leaderboard screen: best-ever run, the last
+// few runs, and running averages.
+type historyModel struct {
+	best     storage.Run
+	bestOK   bool
+	recent   []storage.Run
+	averages storage.Averages
+	loadErr  error
+}
+
+// loadHistoryModel reads everything the history view needs up front; the
+// table is small enough that there is no need to page or stream it.
+func loadHistoryModel(store *storage.Store) historyModel {
+	var m historyModel
+
+	best, ok, err := store.Best()
+	if err != nil {
+		m.loadErr = err
+		return m
+	}
+	m.best, m.bestOK = best, ok
+
+	recent, err := store.Recent(historyRecentLimit)
+	if err != nil {
+		m.loadErr = err
+		return m
+	}
+	m.recent = recent
+
+	averages, err := store.Averages()
+	if err != nil {
+		m.loadErr = err
+		return m
+	}
+	m.averages = averages
+
+	return m
+}
+
+func (m historyModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m historyModel) Update(msg tea.Msg) (historyModel, tea.Cmd) {
+	return m, nil
+}
+
+var historyHeadingStyle = lipgloss.NewStyle().Bold(true)
+
+func (m historyModel) View() string {
+	if m.loadErr != nil {
+		return fmt.Sprintf("failed to load history: %s\n\nesc: back", m.loadErr)
+	}
+
+	var b strings.Builder
+
+	b.WriteString(historyHeadingStyle.Render("Best"))
+	b.WriteString("\n")
+	if m.bestOK {
+		fmt.Fprintf(&b, "%d WPM, %.2f%% acc, %.0f%% con (%s)\n\n", m.best.WPM, m.best.Accuracy, m.best.Consistency, m.best.Mode)
+	} else {
+		b.WriteString("no runs yet\n\n")
+	}
+
+	fmt.Fprintf(&b, "%s (%d runs)\n", historyHeadingStyle.Render("Averages"), m.averages.Runs)
+	fmt.Fprintf(&b, "%.0f WPM, %.2f%% acc, %.0f%% con\n\n", m.averages.WPM, m.averages.Accuracy, m.averages.Consistency)
+
+	b.WriteString(historyHeadingStyle.Render("Last runs"))
+	b.WriteString("\n")
+	if len(m.recent) == 0 {
+		b.WriteString("no runs yet\n")
+	}
+	for _, run := range m.recent {
+		fmt.Fprintf(&b, "%d WPM (%d raw), %.2f%% acc, %.0f%% con, %s (%s)\n",
+			run.WPM, run.RawWPM, run.Accuracy, run.Consistency, run.Mode, run.Language)
+	}
+
+	b.WriteString("\nesc: back")
+
+	return b.String()
+}
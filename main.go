@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"strings"
@@ -12,35 +13,84 @@ import (
 	"github.com/charmbracelet/bubbles/timer"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-)
 
-const DURATION = time.Second * 10
+	"github.com/xGihyun/typetest/session"
+	"github.com/xGihyun/typetest/storage"
+	"github.com/xGihyun/typetest/text"
+	"github.com/xGihyun/typetest/wordlist"
+)
 
 func main() {
-	words, err := getWords(200)
+	cfg := parseFlags()
+
+	if err := cfg.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	sessionText, err := cfg.Text()
 	if err != nil {
 		log.Fatal(err)
 	}
-	text := strings.Join(words, " ")
 
-	p := tea.NewProgram(initialModel(text))
+	store, err := storage.Open()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer store.Close()
+
+	p := tea.NewProgram(newApp(cfg, sessionText, store))
 	if _, err := p.Run(); err != nil {
 		log.Fatal(err)
 	}
 }
 
+// parseFlags builds a session.TestConfig from the CLI flags, falling back
+// to session.DefaultConfig's values (the old hard-coded 10s/200-word test).
+func parseFlags() session.TestConfig {
+	def := session.DefaultConfig()
+
+	mode := flag.String("mode", string(def.Mode), "test mode: time, words, quote, or custom")
+	duration := flag.Duration("duration", def.Duration, "countdown length for --mode time")
+	count := flag.Int("count", def.WordCount, "number of words for --mode words (and the pool size for --mode time)")
+	file := flag.String("file", "", "path to a text file for --mode custom")
+	lang := flag.String("lang", def.Language, "word list language for --mode time/words")
+	punctuation := flag.Bool("punctuation", false, "sprinkle punctuation into the generated words")
+	numbers := flag.Bool("numbers", false, "sprinkle numbers into the generated words")
+	flag.Parse()
+
+	return session.TestConfig{
+		Mode:        session.Mode(*mode),
+		Duration:    *duration,
+		WordCount:   *count,
+		CustomFile:  *file,
+		Language:    *lang,
+		Punctuation: *punctuation,
+		Numbers:     *numbers,
+	}
+}
+
 type model struct {
+	cfg            session.TestConfig
+	store          *storage.Store
 	textInput      textinput.Model
 	help           help.Model
 	keymap         keymap
-	ghostText      string
+	ghostText      text.Buffer
+	ghostKinds     []wordlist.Kind
 	wordsPerMinute int
 	timer          timer.Model
+	timerDuration  time.Duration
 	started        bool
+	startedAt      time.Time
+	finished       bool
 	incorrectCount int
 	accuracy       float32
 	errorPositions map[int]bool
 	maxTyped       int
+	keystrokes     int
+	wpmSamples     []int
+	rawWpmSamples  []int
+	result         session.Result
 }
 
 type keymap struct{}
@@ -55,17 +105,23 @@ func (k keymap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{k.ShortHelp()}
 }
 
-func initialModel(text string) model {
+func initialModel(cfg session.TestConfig, sessionText session.Text, store *storage.Store) model {
 	ti := textinput.New()
 	ti.Width = 80
 	ti.Focus()
 
+	timerDuration := cfg.TimerDuration()
+
 	return model{
+		cfg:            cfg,
+		store:          store,
 		textInput:      ti,
 		help:           help.New(),
 		keymap:         keymap{},
-		ghostText:      text,
-		timer:          timer.New(DURATION),
+		ghostText:      text.NewBuffer(sessionText.Display),
+		ghostKinds:     sessionText.RuneKinds(),
+		timer:          timer.New(timerDuration),
+		timerDuration:  timerDuration,
 		wordsPerMinute: 0,
 		started:        false,
 		accuracy:       100,
@@ -85,83 +141,142 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.finished {
+			return m, nil
+		}
+
 		switch msg.Type {
 		case tea.KeyCtrlC, tea.KeyEsc:
 			return m, tea.Quit
 
 		case tea.KeyBackspace:
-			if cursorPos >= len(m.ghostText) {
+			if cursorPos >= m.ghostText.Len() {
 				break
 			}
 
-			if m.ghostText[cursorPos] == ' ' && m.ghostText[cursorPos-1] == ' ' {
-				m.ghostText = m.ghostText[:cursorPos-1] + m.ghostText[cursorPos:]
+			if m.ghostText.RuneAt(cursorPos) == ' ' && m.ghostText.RuneAt(cursorPos-1) == ' ' {
+				m.ghostText = m.ghostText.Delete(cursorPos-1, cursorPos)
+				m.ghostKinds = append(m.ghostKinds[:cursorPos-1], m.ghostKinds[cursorPos:]...)
 			}
 
 		case tea.KeySpace:
-			if m.timer.Timedout() {
-				return m, nil
+			if cursorPos >= m.ghostText.Len() {
+				break
 			}
 
-			nextWordPos := strings.Index(m.ghostText[cursorPos:], " ")
+			nextWordPos := m.ghostText.IndexRune(cursorPos, ' ')
+			if nextWordPos < 0 {
+				nextWordPos = m.ghostText.Len() - cursorPos
+			}
 			cur := m.textInput.Value()
 			newStr := cur + strings.Repeat(" ", nextWordPos)
 			m.textInput.SetValue(newStr)
-			m.textInput.SetCursor(len(newStr))
+			m.textInput.SetCursor(len([]rune(newStr)))
 
 		case tea.KeyRunes:
-			if m.timer.Timedout() {
-				return m, nil
-			}
+			m.keystrokes += len(msg.Runes)
 
-			if m.ghostText[cursorPos] == ' ' {
-				m.ghostText = m.ghostText[:cursorPos] + " " + m.ghostText[cursorPos:]
+			if cursorPos < m.ghostText.Len() && m.ghostText.RuneAt(cursorPos) == ' ' {
+				m.ghostText = m.ghostText.Insert(cursorPos, " ")
+				m.ghostKinds = append(m.ghostKinds[:cursorPos:cursorPos], append([]wordlist.Kind{wordlist.KindWord}, m.ghostKinds[cursorPos:]...)...)
 			}
 
 			if !m.started {
 				m.started = true
+				m.startedAt = time.Now()
 				initTimerCmd = m.timer.Init()
 			}
 		}
 
 		m.textInput, cmd = m.textInput.Update(msg)
 
+		// A pasted KeyRunes event (bracketed paste delivers the whole
+		// clipboard as one event, spaces included) can advance textInput
+		// past the end of ghostText in a single Update, since ghostText only
+		// ever grows by one rune per event above. Clamp so calculateAccuracy
+		// and the cursor never index past the buffer.
+		if typed := []rune(m.textInput.Value()); len(typed) > m.ghostText.Len() {
+			m.textInput.SetValue(string(typed[:m.ghostText.Len()]))
+			m.textInput.SetCursor(m.ghostText.Len())
+		}
+
 		if m.started {
 			m.calculateAccuracy()
+
+			if m.cfg.Done(false, len([]rune(m.textInput.Value())), m.ghostText.Len()) {
+				m.finish()
+			}
 		}
 
 		return m, tea.Batch(cmd, initTimerCmd)
 
 	case timer.TickMsg:
-		if m.timer.Timedout() {
+		if m.finished {
 			return m, nil
 		}
 
 		v := m.textInput.Value()
-		words := float64(len(v)) / 5.0
-		elapsed := DURATION.Seconds() - m.timer.Timeout.Seconds()
+		vLen := len([]rune(v))
+		words := float64(vLen) / 5.0
+		elapsed := m.timerDuration.Seconds() - m.timer.Timeout.Seconds()
 		if elapsed >= 0.1 {
 			wpm := words * (60 / elapsed)
 			m.wordsPerMinute = int(wpm)
+
+			rawWords := float64(m.keystrokes) / 5.0
+			m.wpmSamples = append(m.wpmSamples, m.wordsPerMinute)
+			m.rawWpmSamples = append(m.rawWpmSamples, int(rawWords*(60/elapsed)))
 		}
 
 		m.timer, cmd = m.timer.Update(msg)
+
+		if m.cfg.Done(m.timer.Timedout(), vLen, m.ghostText.Len()) {
+			m.finish()
+		}
+
 		return m, cmd
 	}
 
 	return m, nil
 }
 
+// finish finalizes the run, computing WPM from the actual time spent typing
+// instead of only on timer expiry, so word/quote/custom modes that end by
+// exhausting the text get a correct result too, and records it to storage.
+func (m *model) finish() {
+	if m.finished {
+		return
+	}
+
+	m.finished = true
+	elapsed := time.Since(m.startedAt)
+	correctCount := len([]rune(m.textInput.Value())) - len(m.errorPositions)
+	m.result = session.Finalize(correctCount, m.keystrokes, elapsed, m.wpmSamples)
+	m.wordsPerMinute = m.result.WordsPerMinute
+
+	if m.store != nil {
+		_, _ = m.store.SaveRun(storage.Run{
+			WPM:         m.wordsPerMinute,
+			RawWPM:      m.result.RawWordsPerMinute,
+			Accuracy:    float64(m.accuracy),
+			Consistency: m.result.Consistency,
+			Mode:        string(m.cfg.Mode),
+			Duration:    elapsed,
+			Language:    m.cfg.Language,
+			Errors:      m.errorPositions,
+			CreatedAt:   m.startedAt.Add(elapsed),
+		})
+	}
+}
+
 func (m *model) calculateAccuracy() {
-	input := m.textInput.Value()
+	input := []rune(m.textInput.Value())
 	if len(input) <= 0 {
 		return
 	}
 
-	for i := 0; i < len(input); i++ {
-		typedChar := input[i]
-		ghostChar := m.ghostText[i]
-		if typedChar != ghostChar {
+	for i, typedChar := range input {
+		if typedChar != m.ghostText.RuneAt(i) {
 			m.errorPositions[i] = true
 		}
 	}
@@ -183,14 +298,55 @@ var (
 	cursorStyle = lipgloss.NewStyle().
 			Background(lipgloss.Color("7")).
 			Foreground(lipgloss.Color("0"))
+	punctuationTextStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("6"))
 )
 
+// ghostStyleFor returns the untyped-text style for the rune at i, letting
+// punctuation and numbers stand out from plain words.
+func (m model) ghostStyleFor(i int) lipgloss.Style {
+	if i < len(m.ghostKinds) && m.ghostKinds[i] != wordlist.KindWord {
+		return punctuationTextStyle
+	}
+	return ghostTextStyle
+}
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders samples as a single line of block characters scaled
+// between their min and max, giving an at-a-glance view of the WPM curve.
+func sparkline(samples []int) string {
+	if len(samples) == 0 {
+		return ""
+	}
+
+	min, max := samples[0], samples[0]
+	for _, s := range samples {
+		if s < min {
+			min = s
+		}
+		if s > max {
+			max = s
+		}
+	}
+
+	spread := max - min
+	runes := make([]rune, len(samples))
+	for i, s := range samples {
+		level := len(sparkBlocks) - 1
+		if spread > 0 {
+			level = (s - min) * (len(sparkBlocks) - 1) / spread
+		}
+		runes[i] = sparkBlocks[level]
+	}
+
+	return string(runes)
+}
+
 func (m model) View() string {
 	var builder strings.Builder
 
-	// NOTE: There might be an issue with using `[]rune` here since we only use `string` on `model.Update`
-	// But we don't use special characters so it would be fine for now.
-	ghostRunes := []rune(m.ghostText)
+	ghostRunes := m.ghostText.Runes()
 	typedRunes := []rune(m.textInput.Value())
 	cursorPos := m.textInput.Position()
 	currentLineLength := 0
@@ -207,7 +363,7 @@ func (m model) View() string {
 			if typedChar == ghostChar {
 				builder.WriteString(correctTextStyle.Render(string(ghostChar)))
 			} else if typedChar == ' ' && ghostChar != ' ' {
-				builder.WriteString(ghostTextStyle.Render(string(ghostChar)))
+				builder.WriteString(m.ghostStyleFor(i).Render(string(ghostChar)))
 			} else {
 				builder.WriteString(incorrectTextStyle.Render(string(typedChar)))
 			}
@@ -215,7 +371,7 @@ func (m model) View() string {
 			if i == cursorPos {
 				builder.WriteString(cursorStyle.Render(string(ghostChar)))
 			} else {
-				builder.WriteString(ghostTextStyle.Render(string(ghostChar)))
+				builder.WriteString(m.ghostStyleFor(i).Render(string(ghostChar)))
 			}
 		}
 
@@ -226,10 +382,11 @@ func (m model) View() string {
 		}
 	}
 
-	if m.timer.Timedout() {
+	if m.finished {
 		m.textInput.Blur()
 		builder.Reset()
-		builder.WriteString(fmt.Sprintf("WPM: %d\nACC: %.2f%%", m.wordsPerMinute, m.accuracy))
+		fmt.Fprintf(&builder, "WPM:  %d\nRAW:  %d\nACC:  %.2f%%\nCON:  %.0f%%\n\n%s",
+			m.wordsPerMinute, m.result.RawWordsPerMinute, m.accuracy, m.result.Consistency, sparkline(m.wpmSamples))
 	}
 
 	return fmt.Sprintf(
@@ -0,0 +1,136 @@
+// Package race coordinates a multiplayer typing race: a shared ghost text,
+// one hub per race broadcasting every player's live progress, and the
+// bubbletea models (lobby, race) rendered over each SSH session.
+package race
+
+import (
+	"sync"
+	"time"
+)
+
+// minPlayers is how many racers must join before the countdown starts.
+// countdown is how long players then have to get ready before typing
+// begins for everyone at once.
+const (
+	minPlayers = 2
+	countdown  = 5 * time.Second
+)
+
+// Player is one connected racer's live state.
+type Player struct {
+	ID       string
+	Name     string
+	Progress int
+	WPM      int
+	Done     bool
+}
+
+// Snapshot is a point-in-time view of every player in a race, sent to
+// subscribers whenever anything changes.
+type Snapshot struct {
+	Players  []Player
+	StartsAt time.Time
+}
+
+// Started reports whether s's countdown has finished and typing should be
+// live for every player.
+func (s Snapshot) Started() bool {
+	return !s.StartsAt.IsZero() && !time.Now().Before(s.StartsAt)
+}
+
+// Hub holds the shared text for a race and broadcasts progress updates to
+// every connected session. One hub is created per race; sessions join it
+// through Join and leave through the returned unsubscribe func.
+type Hub struct {
+	mu          sync.Mutex
+	text        string
+	players     map[string]*Player
+	subscribers map[string]chan Snapshot
+	startsAt    time.Time
+}
+
+// NewHub creates a hub racing the given ghost text.
+func NewHub(text string) *Hub {
+	return &Hub{
+		text:        text,
+		players:     make(map[string]*Player),
+		subscribers: make(map[string]chan Snapshot),
+	}
+}
+
+// Text returns the shared ghost text for the race.
+func (h *Hub) Text() string {
+	return h.text
+}
+
+// Join registers a new player and returns the channel it should read
+// snapshots from, plus a func to call when the session ends.
+func (h *Hub) Join(id, name string) (<-chan Snapshot, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.players[id] = &Player{ID: id, Name: name}
+	sub := make(chan Snapshot, 1)
+	h.subscribers[id] = sub
+
+	if h.startsAt.IsZero() && len(h.players) >= minPlayers {
+		h.startsAt = time.Now().Add(countdown)
+	}
+
+	h.broadcastLocked()
+
+	return sub, func() { h.leave(id) }
+}
+
+func (h *Hub) leave(id string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.players, id)
+	if sub, ok := h.subscribers[id]; ok {
+		close(sub)
+		delete(h.subscribers, id)
+	}
+
+	// A departure during the countdown can drop the race back below
+	// minPlayers; cancel it so it doesn't start short-handed, and let a
+	// later Join that reaches minPlayers again re-arm it. Once the race
+	// has actually started, leaving no longer affects startsAt.
+	if len(h.players) < minPlayers && !h.startsAt.IsZero() && time.Now().Before(h.startsAt) {
+		h.startsAt = time.Time{}
+	}
+
+	h.broadcastLocked()
+}
+
+// UpdateProgress records how far id has typed and re-broadcasts the race
+// state to every player.
+func (h *Hub) UpdateProgress(id string, progress, wpm int, done bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	p, ok := h.players[id]
+	if !ok {
+		return
+	}
+
+	p.Progress, p.WPM, p.Done = progress, wpm, done
+	h.broadcastLocked()
+}
+
+// broadcastLocked sends the current snapshot to every subscriber, dropping
+// it for any subscriber whose channel is still full rather than blocking
+// the race on a slow reader.
+func (h *Hub) broadcastLocked() {
+	snapshot := Snapshot{Players: make([]Player, 0, len(h.players)), StartsAt: h.startsAt}
+	for _, p := range h.players {
+		snapshot.Players = append(snapshot.Players, *p)
+	}
+
+	for _, sub := range h.subscribers {
+		select {
+		case sub <- snapshot:
+		default:
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package race
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// lobbyModel renders the wait screen shown before enough racers have
+// joined, and the countdown once they have.
+type lobbyModel struct {
+	name     string
+	snapshot Snapshot
+}
+
+func newLobbyModel(name string) lobbyModel {
+	return lobbyModel{name: name}
+}
+
+func (m lobbyModel) View() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Lobby (%s)\n\n", m.name)
+
+	for _, p := range m.snapshot.Players {
+		b.WriteString("- " + p.Name + "\n")
+	}
+
+	switch {
+	case len(m.snapshot.Players) < minPlayers:
+		fmt.Fprintf(&b, "\nwaiting for %d more player(s)...\n", minPlayers-len(m.snapshot.Players))
+	case m.snapshot.StartsAt.IsZero():
+		b.WriteString("\nstarting soon...\n")
+	default:
+		left := time.Until(m.snapshot.StartsAt).Round(time.Second)
+		if left < 0 {
+			left = 0
+		}
+		fmt.Fprintf(&b, "\nrace starts in %s\n", left)
+	}
+
+	return b.String()
+}
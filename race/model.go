@@ -0,0 +1,89 @@
+package race
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type screen int
+
+const (
+	screenLobby screen = iota
+	screenRace
+)
+
+// Model is the per-SSH-session bubbletea model: it joins hub on creation,
+// shows the lobby until the countdown ends, then switches to the race.
+type Model struct {
+	screen      screen
+	hub         *Hub
+	id          string
+	sub         <-chan Snapshot
+	unsubscribe func()
+	lobby       lobbyModel
+	race        raceModel
+}
+
+// NewModel joins hub as (id, name) and returns the model to run for that
+// session.
+func NewModel(hub *Hub, id, name string) Model {
+	sub, unsubscribe := hub.Join(id, name)
+
+	return Model{
+		hub:         hub,
+		id:          id,
+		sub:         sub,
+		unsubscribe: unsubscribe,
+		lobby:       newLobbyModel(name),
+		race:        newRaceModel(hub, id),
+	}
+}
+
+type snapshotMsg Snapshot
+
+func waitForSnapshot(sub <-chan Snapshot) tea.Cmd {
+	return func() tea.Msg {
+		snapshot, ok := <-sub
+		if !ok {
+			return nil
+		}
+		return snapshotMsg(snapshot)
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return waitForSnapshot(m.sub)
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case snapshotMsg:
+		snapshot := Snapshot(msg)
+		m.lobby.snapshot = snapshot
+		m.race.snapshot = snapshot
+		if m.screen == screenLobby && snapshot.Started() {
+			m.screen = screenRace
+		}
+		return m, waitForSnapshot(m.sub)
+
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC || msg.Type == tea.KeyEsc {
+			m.unsubscribe()
+			return m, tea.Quit
+		}
+	}
+
+	if m.screen == screenLobby {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.race, cmd = m.race.Update(msg)
+	return m, cmd
+}
+
+func (m Model) View() string {
+	if m.screen == screenLobby {
+		return m.lobby.View()
+	}
+	return m.race.View()
+}
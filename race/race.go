@@ -0,0 +1,128 @@
+package race
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/xGihyun/typetest/text"
+)
+
+var (
+	correctTextStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	incorrectTextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	ghostTextStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	barStyle           = lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+)
+
+// raceModel is the live typing screen for one player once the lobby
+// countdown has finished. Every keystroke is reported to hub so the other
+// racers see this player's progress bar move.
+type raceModel struct {
+	hub       *Hub
+	id        string
+	ghostText text.Buffer
+	textInput textinput.Model
+	startedAt time.Time
+	started   bool
+	finished  bool
+	snapshot  Snapshot
+}
+
+func newRaceModel(hub *Hub, id string) raceModel {
+	ti := textinput.New()
+	ti.Width = 80
+	ti.Focus()
+
+	return raceModel{
+		hub:       hub,
+		id:        id,
+		ghostText: text.NewBuffer(hub.Text()),
+		textInput: ti,
+	}
+}
+
+func (m raceModel) Update(msg tea.Msg) (raceModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || m.finished {
+		return m, nil
+	}
+
+	if keyMsg.Type == tea.KeyRunes && !m.started {
+		m.started = true
+		m.startedAt = time.Now()
+	}
+
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+
+	if m.started {
+		valueLen := len([]rune(m.textInput.Value()))
+		if valueLen >= m.ghostText.Len() {
+			m.finished = true
+		}
+
+		m.hub.UpdateProgress(m.id, valueLen, m.wpm(), m.finished)
+	}
+
+	return m, cmd
+}
+
+func (m raceModel) wpm() int {
+	elapsed := time.Since(m.startedAt).Seconds()
+	if elapsed < 0.1 {
+		return 0
+	}
+	words := float64(len([]rune(m.textInput.Value()))) / 5.0
+	return int(words * (60 / elapsed))
+}
+
+func (m raceModel) View() string {
+	var b strings.Builder
+
+	ghostRunes := m.ghostText.Runes()
+	typedRunes := []rune(m.textInput.Value())
+
+	for i, ghostChar := range ghostRunes {
+		switch {
+		case i < len(typedRunes) && typedRunes[i] == ghostChar:
+			b.WriteString(correctTextStyle.Render(string(ghostChar)))
+		case i < len(typedRunes):
+			b.WriteString(incorrectTextStyle.Render(string(ghostChar)))
+		default:
+			b.WriteString(ghostTextStyle.Render(string(ghostChar)))
+		}
+	}
+
+	b.WriteString("\n\n")
+	for _, p := range m.snapshot.Players {
+		b.WriteString(progressBar(p, len(ghostRunes)))
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+const barWidth = 40
+
+func progressBar(p Player, textLen int) string {
+	filled := barWidth
+	if textLen > 0 {
+		filled = p.Progress * barWidth / textLen
+		if filled > barWidth {
+			filled = barWidth
+		}
+	}
+
+	bar := barStyle.Render(strings.Repeat("=", filled)) + strings.Repeat(" ", barWidth-filled)
+	status := ""
+	if p.Done {
+		status = " done"
+	}
+
+	return fmt.Sprintf("%-12s [%s] %d wpm%s", p.Name, bar, p.WPM, status)
+}
@@ -0,0 +1,122 @@
+// Package session defines the configurable test modes (time, words, quote,
+// custom) and the logic that drives how a run's source text is generated
+// and when it ends.
+package session
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/xGihyun/typetest/wordlist"
+)
+
+// Mode selects how the test's text is generated and how it ends.
+type Mode string
+
+const (
+	ModeTime   Mode = "time"
+	ModeWords  Mode = "words"
+	ModeQuote  Mode = "quote"
+	ModeCustom Mode = "custom"
+)
+
+// DefaultDuration and DefaultWordCount match the behavior of the original
+// hard-coded test (10 seconds, 200 words) when no flags are passed.
+const (
+	DefaultDuration  = 10 * time.Second
+	DefaultWordCount = 200
+)
+
+// TestConfig describes a single run: which mode to use and the parameters
+// that mode needs.
+type TestConfig struct {
+	Mode Mode
+
+	// Duration is the countdown used by ModeTime.
+	Duration time.Duration
+	// WordCount is the number of words to generate for ModeWords, and the
+	// fixed-size pool generated once for ModeTime. A ModeTime run still ends
+	// early if the player finishes that pool before the countdown does.
+	WordCount int
+	// CustomFile is the path to read from for ModeCustom.
+	CustomFile string
+
+	// Language selects the embedded word pool used by ModeTime/ModeWords.
+	Language string
+	// Punctuation and Numbers enable the wordlist generator modifiers.
+	Punctuation bool
+	Numbers     bool
+}
+
+// DefaultConfig returns the config equivalent to the test's old behavior.
+func DefaultConfig() TestConfig {
+	return TestConfig{
+		Mode:      ModeTime,
+		Duration:  DefaultDuration,
+		WordCount: DefaultWordCount,
+		Language:  wordlist.DefaultLanguage,
+	}
+}
+
+// Validate reports whether c is runnable, returning an error describing the
+// first problem found.
+func (c TestConfig) Validate() error {
+	switch c.Mode {
+	case ModeTime:
+		if c.Duration <= 0 {
+			return fmt.Errorf("session: --duration must be positive, got %s", c.Duration)
+		}
+		if _, err := wordlist.Load(c.Language); err != nil {
+			return err
+		}
+	case ModeWords:
+		if c.WordCount <= 0 {
+			return fmt.Errorf("session: --count must be positive, got %d", c.WordCount)
+		}
+		if _, err := wordlist.Load(c.Language); err != nil {
+			return err
+		}
+	case ModeQuote:
+		// no parameters to validate
+	case ModeCustom:
+		if c.CustomFile == "" {
+			return fmt.Errorf("session: --file is required for --mode custom")
+		}
+	default:
+		return fmt.Errorf("session: unknown mode %q", c.Mode)
+	}
+
+	return nil
+}
+
+// HasTimer reports whether the mode ends via a countdown rather than by
+// exhausting the text.
+func (c TestConfig) HasTimer() bool {
+	return c.Mode == ModeTime
+}
+
+// uncappedDuration is handed to bubbles/timer for modes that end by
+// exhausting the text rather than timing out. timer.Model stops emitting
+// TickMsg once its countdown reaches zero, so without this the live
+// WPM/TIME readout (and wpmSamples) would freeze partway through any
+// words/quote/custom run longer than the default duration.
+const uncappedDuration = 24 * time.Hour
+
+// TimerDuration returns the countdown to start bubbles/timer with: c.Duration
+// for ModeTime, or uncappedDuration for modes that end on their own once the
+// text is exhausted.
+func (c TestConfig) TimerDuration() time.Duration {
+	if c.HasTimer() {
+		return c.Duration
+	}
+	return uncappedDuration
+}
+
+// Done reports whether a run should end, given how much of the ghost text
+// has been typed and, for timed modes, how much time is left. Timed modes
+// still end on text exhaustion too: ModeTime's pool is generated once up
+// front, not regenerated as it runs low, so a player who finishes it before
+// the countdown ends must stop there rather than run off the end of the text.
+func (c TestConfig) Done(timedOut bool, typedLen, textLen int) bool {
+	return timedOut || typedLen >= textLen
+}
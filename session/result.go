@@ -0,0 +1,70 @@
+package session
+
+import (
+	"math"
+	"time"
+)
+
+// Result is the outcome of a finished run.
+type Result struct {
+	WordsPerMinute    int
+	RawWordsPerMinute int
+	Consistency       float64
+}
+
+// Finalize computes the result for a run that just ended, whether by timer
+// expiry (ModeTime) or by exhausting the text (ModeWords/ModeQuote/
+// ModeCustom). elapsed is the time actually spent typing; correctCount is
+// the number of correctly-typed characters (net WPM) and keystrokes is
+// every rune typed including mistakes (raw WPM). wpmSamples are the
+// per-tick net WPM snapshots taken over the run, used for Consistency.
+func Finalize(correctCount, keystrokes int, elapsed time.Duration, wpmSamples []int) Result {
+	if elapsed <= 0 {
+		return Result{}
+	}
+
+	minutes := elapsed.Seconds() / 60
+	net := int(float64(correctCount) / 5.0 / minutes)
+	raw := int(float64(keystrokes) / 5.0 / minutes)
+
+	return Result{
+		WordsPerMinute:    net,
+		RawWordsPerMinute: raw,
+		Consistency:       Consistency(wpmSamples),
+	}
+}
+
+// Consistency scores how steady the WPM samples were over a run as a
+// coefficient-of-variation-based score in [0, 100]: 100 means every sample
+// matched the mean, 0 means the spread was as wide as the mean itself.
+func Consistency(wpmSamples []int) float64 {
+	if len(wpmSamples) < 2 {
+		return 100
+	}
+
+	var sum float64
+	for _, s := range wpmSamples {
+		sum += float64(s)
+	}
+	mean := sum / float64(len(wpmSamples))
+	if mean <= 0 {
+		return 0
+	}
+
+	var variance float64
+	for _, s := range wpmSamples {
+		d := float64(s) - mean
+		variance += d * d
+	}
+	variance /= float64(len(wpmSamples))
+	stddev := math.Sqrt(variance)
+
+	score := 100 * (1 - stddev/mean)
+	if score < 0 {
+		return 0
+	}
+	if score > 100 {
+		return 100
+	}
+	return score
+}
@@ -0,0 +1,73 @@
+package session
+
+import (
+	"math/rand"
+	"os"
+	"strings"
+
+	"github.com/xGihyun/typetest/wordlist"
+)
+
+// Text is the generated source for a run: the display string plus
+// per-token metadata so the renderer can style punctuation and numbers
+// differently from plain words.
+type Text struct {
+	Display string
+	Tokens  []wordlist.Token
+}
+
+// quotes is a small built-in pool used by ModeQuote until a proper quote
+// source is wired up.
+var quotes = []string{
+	"The quick brown fox jumps over the lazy dog.",
+	"Not all those who wander are lost.",
+	"In the middle of difficulty lies opportunity.",
+}
+
+// Text generates the ghost text for a run according to c.Mode.
+func (c TestConfig) Text() (Text, error) {
+	switch c.Mode {
+	case ModeTime, ModeWords:
+		words, err := wordlist.Generate(c.Language, c.WordCount, wordlist.Options{
+			Punctuation: c.Punctuation,
+			Numbers:     c.Numbers,
+		})
+		if err != nil {
+			return Text{}, err
+		}
+		return Text{Display: words.Display, Tokens: words.Tokens}, nil
+
+	case ModeQuote:
+		quote := quotes[rand.Intn(len(quotes))]
+		return Text{Display: quote, Tokens: []wordlist.Token{{Text: quote, Kind: wordlist.KindWord}}}, nil
+
+	case ModeCustom:
+		contents, err := os.ReadFile(c.CustomFile)
+		if err != nil {
+			return Text{}, err
+		}
+		custom := strings.TrimSpace(string(contents))
+		return Text{Display: custom, Tokens: []wordlist.Token{{Text: custom, Kind: wordlist.KindWord}}}, nil
+
+	default:
+		return Text{}, nil
+	}
+}
+
+// RuneKinds expands t's per-token kinds into one entry per rune of
+// t.Display (spaces between tokens are KindWord), so the renderer can index
+// it alongside the ghost text.
+func (t Text) RuneKinds() []wordlist.Kind {
+	kinds := make([]wordlist.Kind, 0, len(t.Display))
+
+	for i, tok := range t.Tokens {
+		if i > 0 {
+			kinds = append(kinds, wordlist.KindWord)
+		}
+		for range []rune(tok.Text) {
+			kinds = append(kinds, tok.Kind)
+		}
+	}
+
+	return kinds
+}
@@ -0,0 +1,196 @@
+// Package storage persists finished runs to a local SQLite database so
+// past results can be reviewed in a history/leaderboard view.
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS runs (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	wpm         INTEGER NOT NULL,
+	raw_wpm     INTEGER NOT NULL,
+	accuracy    REAL NOT NULL,
+	consistency REAL NOT NULL,
+	mode        TEXT NOT NULL,
+	duration_ms INTEGER NOT NULL,
+	language    TEXT NOT NULL,
+	errors      TEXT NOT NULL,
+	created_at  DATETIME NOT NULL
+);
+`
+
+// Run is a single finished test, as recorded to the database.
+type Run struct {
+	ID          int64
+	WPM         int
+	RawWPM      int
+	Accuracy    float64
+	Consistency float64
+	Mode        string
+	Duration    time.Duration
+	Language    string
+	Errors      map[int]bool
+	CreatedAt   time.Time
+}
+
+// Store wraps the SQLite connection used to save and query runs.
+type Store struct {
+	db *sql.DB
+}
+
+// Open creates (if needed) and opens the results database under the user's
+// config dir, e.g. ~/.config/typetest/typetest.db.
+func Open() (*Store, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("storage: resolve config dir: %w", err)
+	}
+
+	dir = filepath.Join(dir, "typetest")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("storage: create config dir: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", filepath.Join(dir, "typetest.db"))
+	if err != nil {
+		return nil, fmt.Errorf("storage: open database: %w", err)
+	}
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("storage: migrate schema: %w", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close releases the underlying database connection.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// SaveRun records a finished run.
+func (s *Store) SaveRun(run Run) (Run, error) {
+	errs, err := json.Marshal(run.Errors)
+	if err != nil {
+		return Run{}, fmt.Errorf("storage: marshal errors: %w", err)
+	}
+
+	res, err := s.db.Exec(
+		`INSERT INTO runs (wpm, raw_wpm, accuracy, consistency, mode, duration_ms, language, errors, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		run.WPM, run.RawWPM, run.Accuracy, run.Consistency, run.Mode,
+		run.Duration.Milliseconds(), run.Language, string(errs), run.CreatedAt,
+	)
+	if err != nil {
+		return Run{}, fmt.Errorf("storage: save run: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return Run{}, fmt.Errorf("storage: save run: %w", err)
+	}
+
+	run.ID = id
+	return run, nil
+}
+
+// Recent returns up to limit runs, most recent first.
+func (s *Store) Recent(limit int) ([]Run, error) {
+	rows, err := s.db.Query(
+		`SELECT id, wpm, raw_wpm, accuracy, consistency, mode, duration_ms, language, errors, created_at
+		 FROM runs ORDER BY created_at DESC LIMIT ?`,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("storage: query recent runs: %w", err)
+	}
+	defer rows.Close()
+
+	return scanRuns(rows)
+}
+
+// Best returns the run with the highest WPM, or ok=false if none exist.
+func (s *Store) Best() (run Run, ok bool, err error) {
+	row := s.db.QueryRow(
+		`SELECT id, wpm, raw_wpm, accuracy, consistency, mode, duration_ms, language, errors, created_at
+		 FROM runs ORDER BY wpm DESC LIMIT 1`,
+	)
+
+	run, err = scanRun(row)
+	if err == sql.ErrNoRows {
+		return Run{}, false, nil
+	}
+	if err != nil {
+		return Run{}, false, fmt.Errorf("storage: query best run: %w", err)
+	}
+
+	return run, true, nil
+}
+
+// Averages summarizes WPM, accuracy, and consistency across every run.
+type Averages struct {
+	Runs        int
+	WPM         float64
+	Accuracy    float64
+	Consistency float64
+}
+
+// Averages computes the averages across all recorded runs.
+func (s *Store) Averages() (Averages, error) {
+	var avg Averages
+	row := s.db.QueryRow(`SELECT COUNT(*), COALESCE(AVG(wpm), 0), COALESCE(AVG(accuracy), 0), COALESCE(AVG(consistency), 0) FROM runs`)
+	if err := row.Scan(&avg.Runs, &avg.WPM, &avg.Accuracy, &avg.Consistency); err != nil {
+		return Averages{}, fmt.Errorf("storage: query averages: %w", err)
+	}
+
+	return avg, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanRun(r rowScanner) (Run, error) {
+	var run Run
+	var durationMs int64
+	var errs string
+
+	err := r.Scan(
+		&run.ID, &run.WPM, &run.RawWPM, &run.Accuracy, &run.Consistency,
+		&run.Mode, &durationMs, &run.Language, &errs, &run.CreatedAt,
+	)
+	if err != nil {
+		return Run{}, err
+	}
+
+	run.Duration = time.Duration(durationMs) * time.Millisecond
+
+	if err := json.Unmarshal([]byte(errs), &run.Errors); err != nil {
+		return Run{}, fmt.Errorf("storage: unmarshal errors: %w", err)
+	}
+
+	return run, nil
+}
+
+func scanRuns(rows *sql.Rows) ([]Run, error) {
+	var runs []Run
+	for rows.Next() {
+		run, err := scanRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+
+	return runs, rows.Err()
+}
@@ -0,0 +1,70 @@
+// Package text provides a rune-indexed buffer so cursor arithmetic and
+// error-position tracking never split a multi-byte character.
+package text
+
+// Buffer wraps a []rune so all indexing is by rune, not by byte. Ghost
+// text and typed input both need the same indexing scheme for their
+// positions to line up; a plain string compared byte-by-byte corrupts any
+// multi-byte rune (accented Latin, Cyrillic, CJK, ...).
+type Buffer struct {
+	runes []rune
+}
+
+// NewBuffer creates a Buffer from s.
+func NewBuffer(s string) Buffer {
+	return Buffer{runes: []rune(s)}
+}
+
+// Len returns the number of runes in the buffer.
+func (b Buffer) Len() int {
+	return len(b.runes)
+}
+
+// RuneAt returns the rune at index i.
+func (b Buffer) RuneAt(i int) rune {
+	return b.runes[i]
+}
+
+// SliceByRune returns the substring spanning rune indices [a, b).
+func (b Buffer) SliceByRune(a, to int) string {
+	return string(b.runes[a:to])
+}
+
+// Runes returns the buffer's contents as a []rune. The caller must not
+// mutate the result.
+func (b Buffer) Runes() []rune {
+	return b.runes
+}
+
+// String returns the buffer's contents as a string.
+func (b Buffer) String() string {
+	return string(b.runes)
+}
+
+// IndexRune returns the rune offset, relative to from, of the next
+// occurrence of r at or after from, or -1 if there is none.
+func (b Buffer) IndexRune(from int, r rune) int {
+	for i := from; i < len(b.runes); i++ {
+		if b.runes[i] == r {
+			return i - from
+		}
+	}
+	return -1
+}
+
+// Insert returns a copy of b with s inserted before rune index i.
+func (b Buffer) Insert(i int, s string) Buffer {
+	out := make([]rune, 0, len(b.runes)+len(s))
+	out = append(out, b.runes[:i]...)
+	out = append(out, []rune(s)...)
+	out = append(out, b.runes[i:]...)
+	return Buffer{runes: out}
+}
+
+// Delete returns a copy of b with the runes in [a, to) removed.
+func (b Buffer) Delete(a, to int) Buffer {
+	out := make([]rune, 0, len(b.runes)-(to-a))
+	out = append(out, b.runes[:a]...)
+	out = append(out, b.runes[to:]...)
+	return Buffer{runes: out}
+}
@@ -0,0 +1,64 @@
+package text
+
+import "testing"
+
+func TestBufferRuneAt(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want []rune
+	}{
+		{"accented latin", "café résumé", []rune("café résumé")},
+		{"cyrillic", "Привет мир", []rune("Привет мир")},
+		{"cjk", "日本語を勉強する", []rune("日本語を勉強する")},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			b := NewBuffer(c.in)
+
+			if b.Len() != len(c.want) {
+				t.Fatalf("Len() = %d, want %d", b.Len(), len(c.want))
+			}
+
+			for i, want := range c.want {
+				if got := b.RuneAt(i); got != want {
+					t.Errorf("RuneAt(%d) = %q, want %q", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBufferSliceByRune(t *testing.T) {
+	b := NewBuffer("日本語を勉強する")
+
+	if got, want := b.SliceByRune(0, 3), "日本語"; got != want {
+		t.Errorf("SliceByRune(0, 3) = %q, want %q", got, want)
+	}
+}
+
+func TestBufferInsertDelete(t *testing.T) {
+	b := NewBuffer("Привет мир")
+
+	inserted := b.Insert(7, "большой ")
+	if want := "Привет большой мир"; inserted.String() != want {
+		t.Errorf("Insert = %q, want %q", inserted.String(), want)
+	}
+
+	deleted := inserted.Delete(7, 15)
+	if want := "Привет мир"; deleted.String() != want {
+		t.Errorf("Delete = %q, want %q", deleted.String(), want)
+	}
+}
+
+func TestBufferIndexRune(t *testing.T) {
+	b := NewBuffer("café au lait")
+
+	if got, want := b.IndexRune(0, ' '), 4; got != want {
+		t.Errorf("IndexRune(0, ' ') = %d, want %d", got, want)
+	}
+	if got := b.IndexRune(0, '€'); got != -1 {
+		t.Errorf("IndexRune for absent rune = %d, want -1", got)
+	}
+}
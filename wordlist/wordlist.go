@@ -0,0 +1,122 @@
+// Package wordlist embeds the per-language word pools and generates the
+// token sequence a run types, optionally augmented with punctuation and
+// numbers.
+package wordlist
+
+import (
+	"embed"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+//go:embed data/*.txt
+var data embed.FS
+
+// DefaultLanguage is used when no --lang flag is given.
+const DefaultLanguage = "english"
+
+// Kind classifies a token so the renderer can style it differently.
+type Kind int
+
+const (
+	KindWord Kind = iota
+	KindPunctuation
+	KindNumber
+)
+
+// Token is a single generated word, punctuation mark, or number.
+type Token struct {
+	Text string
+	Kind Kind
+}
+
+// Words is a generated run's text plus per-token metadata.
+type Words struct {
+	Display string
+	Tokens  []Token
+}
+
+// Options controls the punctuation/number modifiers applied on top of the
+// plain word pool.
+type Options struct {
+	Punctuation bool
+	Numbers     bool
+}
+
+// punctuationMarks are appended to a word when the punctuation modifier
+// fires for it.
+var punctuationMarks = []string{",", ".", "!", "?", ";", "\"%s\""}
+
+const (
+	punctuationChance = 0.12
+	numberChance      = 0.08
+)
+
+// Load reads every word in lang's embedded list.
+func Load(lang string) ([]string, error) {
+	contents, err := data.ReadFile(fmt.Sprintf("data/%s.txt", lang))
+	if err != nil {
+		return nil, fmt.Errorf("wordlist: unknown language %q: %w", lang, err)
+	}
+
+	var words []string
+	for _, w := range strings.Split(string(contents), "\n") {
+		w = strings.TrimSpace(w)
+		if w != "" {
+			words = append(words, w)
+		}
+	}
+
+	return words, nil
+}
+
+// Generate picks count random words from lang's pool and applies opts'
+// modifiers, returning the display text and per-token metadata.
+func Generate(lang string, count int, opts Options) (Words, error) {
+	pool, err := Load(lang)
+	if err != nil {
+		return Words{}, err
+	}
+
+	tokens := make([]Token, 0, count)
+	for range count {
+		word := Token{Text: pool[rand.Intn(len(pool))], Kind: KindWord}
+
+		if opts.Punctuation && rand.Float64() < punctuationChance {
+			word = applyPunctuation(word)
+		}
+
+		tokens = append(tokens, word)
+
+		if opts.Numbers && rand.Float64() < numberChance {
+			tokens = append(tokens, Token{Text: strconv.Itoa(rand.Intn(1000)), Kind: KindNumber})
+		}
+	}
+
+	var b strings.Builder
+	for i, t := range tokens {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(t.Text)
+	}
+
+	return Words{Display: b.String(), Tokens: tokens}, nil
+}
+
+// applyPunctuation appends or wraps a word token with a random mark,
+// reclassifying it as punctuation.
+func applyPunctuation(t Token) Token {
+	mark := punctuationMarks[rand.Intn(len(punctuationMarks))]
+
+	text := t.Text
+	if strings.Contains(mark, "%s") {
+		text = fmt.Sprintf(mark, text)
+	} else {
+		text += mark
+	}
+
+	return Token{Text: text, Kind: KindPunctuation}
+}